@@ -0,0 +1,132 @@
+package nostr
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SigningMode selects the digest framing used when signing or verifying an
+// Event.
+type SigningMode int
+
+const (
+	// SigningModePersonal hashes the event under the EIP-191
+	// personal_sign framing, as computed by prefixedDigest. This is the
+	// default and preserves the original GetID/CheckID behavior.
+	SigningModePersonal SigningMode = iota
+
+	// SigningModeEIP712 hashes the event as EIP-712 typed data, via
+	// GetTypedDataHash. This is friendlier to wallets such as MetaMask
+	// or WalletConnect, which can display the event fields instead of
+	// an opaque personal_sign prefix.
+	SigningModeEIP712
+)
+
+// TypedDataDomain is the EIP-712 domain separator input used by
+// GetTypedDataHash.
+type TypedDataDomain struct {
+	Name    string
+	Version string
+	ChainID *big.Int
+}
+
+// DefaultTypedDataDomain returns the domain used by Nostr's EIP-712 typed
+// data mode: name "Nostr", version "1", for the given chain ID.
+func DefaultTypedDataDomain(chainID *big.Int) TypedDataDomain {
+	return TypedDataDomain{Name: "Nostr", Version: "1", ChainID: chainID}
+}
+
+func (evt *Event) effectiveTypedDataDomain() TypedDataDomain {
+	if evt.TypedDataDomain != nil {
+		return *evt.TypedDataDomain
+	}
+	return DefaultTypedDataDomain(big.NewInt(1))
+}
+
+var (
+	eip712DomainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId)"))
+	eventTypeHash        = crypto.Keccak256Hash([]byte("Event(address pubkey,uint64 created_at,uint32 kind,string tags,string content)"))
+)
+
+// domainSeparator implements hashStruct(domain) for the EIP712Domain type.
+func (d TypedDataDomain) domainSeparator() [32]byte {
+	nameHash := crypto.Keccak256Hash([]byte(d.Name))
+	versionHash := crypto.Keccak256Hash([]byte(d.Version))
+
+	var chainID [32]byte
+	if d.ChainID != nil {
+		d.ChainID.FillBytes(chainID[:])
+	}
+
+	return crypto.Keccak256Hash(
+		eip712DomainTypeHash.Bytes(),
+		nameHash.Bytes(),
+		versionHash.Bytes(),
+		chainID[:],
+	)
+}
+
+// hashStruct implements hashStruct(event) for the Event type described
+// above: pubkey is encoded as a left-padded address, created_at and kind as
+// left-padded integers, and tags/content as the keccak256 of their
+// canonical encodings (the EIP-712 rule for dynamic types).
+func (evt *Event) hashStruct() ([32]byte, error) {
+	pubKeyBytes, err := hex.DecodeString(evt.PubKey)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid PubKey: %w", err)
+	}
+	if len(pubKeyBytes) != 20 {
+		return [32]byte{}, fmt.Errorf("invalid PubKey: expected a 20-byte address, got %d bytes", len(pubKeyBytes))
+	}
+	var pubKeyWord [32]byte
+	copy(pubKeyWord[32-len(pubKeyBytes):], pubKeyBytes)
+
+	var createdAtWord, kindWord [32]byte
+	new(big.Int).SetUint64(uint64(evt.CreatedAt)).FillBytes(createdAtWord[:])
+	new(big.Int).SetUint64(uint64(evt.Kind)).FillBytes(kindWord[:])
+
+	tagsHash := crypto.Keccak256Hash(evt.Tags.Serialize())
+	contentHash := crypto.Keccak256Hash([]byte(evt.Content))
+
+	return crypto.Keccak256Hash(
+		eventTypeHash.Bytes(),
+		pubKeyWord[:],
+		createdAtWord[:],
+		kindWord[:],
+		tagsHash.Bytes(),
+		contentHash.Bytes(),
+	), nil
+}
+
+// typedDataHash is the error-returning implementation behind
+// GetTypedDataHash, used internally by digest() so that verifying an
+// untrusted event (CheckID, CheckSignature) can reject a malformed PubKey
+// instead of panicking on it.
+func (evt *Event) typedDataHash(domain TypedDataDomain) ([]byte, error) {
+	separator := domain.domainSeparator()
+	structHash, err := evt.hashStruct()
+	if err != nil {
+		return nil, err
+	}
+
+	h := crypto.Keccak256Hash([]byte{0x19, 0x01}, separator[:], structHash[:])
+	return h.Bytes(), nil
+}
+
+// GetTypedDataHash computes the standard EIP-712 digest
+// keccak256("\x19\x01" || domainSeparator || hashStruct(Event)) for the
+// given domain, suitable for signing with eth_signTypedData_v4.
+// evt.PubKey must already be a valid 20-byte hex address (as Sign leaves
+// it); GetTypedDataHash panics otherwise rather than return a digest over
+// corrupt data. Prefer CheckID/CheckSignature for untrusted events, since
+// those validate instead of panicking.
+func (evt *Event) GetTypedDataHash(domain TypedDataDomain) []byte {
+	h, err := evt.typedDataHash(domain)
+	if err != nil {
+		panic(fmt.Sprintf("nostr: GetTypedDataHash: %s", err))
+	}
+	return h
+}
@@ -0,0 +1,110 @@
+package nostr
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEventEIP712SignAndCheckSignature(t *testing.T) {
+	evt := &Event{
+		CreatedAt:       Timestamp(12345),
+		Kind:            1,
+		Tags:            Tags{},
+		Content:         "hello from eip-712",
+		SigningMode:     SigningModeEIP712,
+		TypedDataDomain: domainPtr(DefaultTypedDataDomain(big.NewInt(1))),
+	}
+
+	if err := evt.Sign(testPrivateKeyHex); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	ok, err := evt.CheckSignature()
+	if err != nil {
+		t.Fatalf("CheckSignature: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected EIP-712 signature to be valid")
+	}
+
+	// the ID should be the typed data hash, not the personal_sign digest
+	other := &Event{
+		CreatedAt: evt.CreatedAt,
+		Kind:      evt.Kind,
+		Tags:      evt.Tags,
+		Content:   evt.Content,
+		PubKey:    evt.PubKey,
+	}
+	if other.GetID() == evt.GetID() {
+		t.Fatalf("expected EIP-712 and personal_sign digests to differ")
+	}
+}
+
+func TestGetTypedDataHashIsDeterministic(t *testing.T) {
+	evt := &Event{
+		PubKey:    "d8da6bf26964af9d7eed9e03e53415d37aa96045",
+		CreatedAt: Timestamp(1700000000),
+		Kind:      1,
+		Tags:      Tags{{"e", "abc"}},
+		Content:   "gm",
+	}
+	domain := DefaultTypedDataDomain(big.NewInt(1))
+
+	h1 := evt.GetTypedDataHash(domain)
+	h2 := evt.GetTypedDataHash(domain)
+	if len(h1) != 32 {
+		t.Fatalf("expected a 32-byte digest, got %d", len(h1))
+	}
+	if string(h1) != string(h2) {
+		t.Fatalf("expected GetTypedDataHash to be deterministic")
+	}
+
+	evt.Content = "gn"
+	h3 := evt.GetTypedDataHash(domain)
+	if string(h1) == string(h3) {
+		t.Fatalf("expected a different digest after changing the content")
+	}
+}
+
+func domainPtr(d TypedDataDomain) *TypedDataDomain { return &d }
+
+func TestGetTypedDataHashPanicsOnInvalidPubKey(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected GetTypedDataHash to panic on an invalid PubKey")
+		}
+	}()
+
+	evt := &Event{
+		PubKey:    "not-hex",
+		CreatedAt: Timestamp(1700000000),
+		Kind:      1,
+		Tags:      Tags{},
+		Content:   "gm",
+	}
+	evt.GetTypedDataHash(DefaultTypedDataDomain(big.NewInt(1)))
+}
+
+func TestCheckSignatureRejectsInvalidPubKeyInsteadOfPanicking(t *testing.T) {
+	evt := &Event{
+		CreatedAt:       Timestamp(12345),
+		Kind:            1,
+		Tags:            Tags{},
+		Content:         "hello from eip-712",
+		SigningMode:     SigningModeEIP712,
+		TypedDataDomain: domainPtr(DefaultTypedDataDomain(big.NewInt(1))),
+	}
+	if err := evt.Sign(testPrivateKeyHex); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	evt.PubKey = "not-hex"
+
+	ok, err := evt.CheckSignature()
+	if err == nil {
+		t.Fatalf("expected CheckSignature to return an error for an invalid PubKey")
+	}
+	if ok {
+		t.Fatalf("expected CheckSignature to return false for an invalid PubKey")
+	}
+}
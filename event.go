@@ -2,7 +2,9 @@ package nostr
 
 import (
     "fmt"
+	"crypto/subtle"
 	"encoding/hex"
+	"math/big"
 	"strconv"
 
 	"github.com/mailru/easyjson"
@@ -18,6 +20,17 @@ type Event struct {
 	Tags      Tags
 	Content   string
 	Sig       string
+
+	// SigningMode selects the digest framing used by GetID, CheckID, Sign
+	// and CheckSignature. It defaults to SigningModePersonal (the
+	// EIP-191 "\x19Ethereum Signed Message:\n" framing); set it to
+	// SigningModeEIP712 to sign/verify over GetTypedDataHash instead.
+	SigningMode SigningMode
+
+	// TypedDataDomain is the EIP-712 domain used when SigningMode is
+	// SigningModeEIP712. If nil, DefaultTypedDataDomain(big.NewInt(1))
+	// is used.
+	TypedDataDomain *TypedDataDomain
 }
 
 func (evt Event) String() string {
@@ -25,19 +38,46 @@ func (evt Event) String() string {
 	return string(j)
 }
 
-// GetID computes the event ID and returns it as a hex string.
-func (evt *Event) GetID() string {
-    message := evt.Serialize()
+// prefixedDigest returns the Keccak256 hash of the event's NIP-01
+// serialization under the EIP-191 personal_sign framing
+// ("\x19Ethereum Signed Message:\n<len>").
+func (evt *Event) prefixedDigest() [32]byte {
+	message := evt.Serialize()
 	prefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)
-	h:= crypto.Keccak256Hash([]byte(prefixedMessage))
-	return hex.EncodeToString(h.Bytes())
+	return crypto.Keccak256Hash([]byte(prefixedMessage))
+}
+
+// digest returns the 32-byte hash that GetID, CheckID, Sign and
+// CheckSignature all operate on, chosen according to evt.SigningMode. It
+// returns an error rather than panicking so callers verifying events from
+// an untrusted source (CheckID, CheckSignature) can reject a malformed
+// PubKey instead of crashing on it.
+func (evt *Event) digest() ([]byte, error) {
+	if evt.SigningMode == SigningModeEIP712 {
+		return evt.typedDataHash(evt.effectiveTypedDataDomain())
+	}
+	d := evt.prefixedDigest()
+	return d[:], nil
+}
+
+// GetID computes the event ID and returns it as a hex string. It panics if
+// evt's fields (e.g. PubKey under SigningModeEIP712) aren't yet valid to
+// hash; callers constructing an event locally are expected to uphold that.
+func (evt *Event) GetID() string {
+	d, err := evt.digest()
+	if err != nil {
+		panic(fmt.Sprintf("nostr: GetID: %s", err))
+	}
+	return hex.EncodeToString(d)
 }
 
 // CheckID checks if the implied ID matches the given ID more efficiently.
+// It returns false, rather than panicking, if evt's fields can't be hashed.
 func (evt *Event) CheckID() bool {
-    message := evt.Serialize()
-	prefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)
-	h:= crypto.Keccak256Hash([]byte(prefixedMessage))
+	h, err := evt.digest()
+	if err != nil {
+		return false
+	}
 
 	const hextable = "0123456789abcdef"
 
@@ -56,6 +96,71 @@ func (evt *Event) CheckID() bool {
 	return true
 }
 
+// Sign signs the event with the given hex-encoded secp256k1 private key,
+// producing a 65-byte recoverable ECDSA signature over the same digest
+// computed by GetID (chosen by evt.SigningMode). It populates evt.Sig,
+// evt.PubKey and evt.ID.
+func (evt *Event) Sign(hexPrivateKey string) error {
+	priv, err := crypto.HexToECDSA(hexPrivateKey)
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+
+	evt.PubKey = hex.EncodeToString(crypto.PubkeyToAddress(priv.PublicKey).Bytes())
+
+	d, err := evt.digest()
+	if err != nil {
+		return fmt.Errorf("error computing digest: %w", err)
+	}
+	evt.ID = hex.EncodeToString(d)
+
+	sig, err := crypto.Sign(d, priv)
+	if err != nil {
+		return fmt.Errorf("error signing event: %w", err)
+	}
+
+	evt.Sig = hex.EncodeToString(sig)
+	return nil
+}
+
+// CheckSignature checks if the signature is valid for the event's
+// digest (chosen by evt.SigningMode) and was produced by the holder of
+// evt.PubKey. It rejects
+// malleable high-S signatures. It returns an error rather than panicking
+// for any malformed field of evt, since evt may come from an untrusted peer.
+func (evt *Event) CheckSignature() (bool, error) {
+	sig, err := hex.DecodeString(evt.Sig)
+	if err != nil {
+		return false, fmt.Errorf("signature is invalid hex: %w", err)
+	}
+	if len(sig) != 65 {
+		return false, fmt.Errorf("signature must be 65 bytes, got %d", len(sig))
+	}
+
+	r := new(big.Int).SetBytes(sig[0:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if !crypto.ValidateSignatureValues(sig[64], r, s, true) {
+		return false, fmt.Errorf("signature has malleable or out-of-range values")
+	}
+
+	d, err := evt.digest()
+	if err != nil {
+		return false, fmt.Errorf("invalid event: %w", err)
+	}
+
+	pub, err := crypto.SigToPub(d, sig)
+	if err != nil {
+		return false, fmt.Errorf("failed to recover public key: %w", err)
+	}
+
+	recovered := hex.EncodeToString(crypto.PubkeyToAddress(*pub).Bytes())
+	if subtle.ConstantTimeCompare([]byte(recovered), []byte(evt.PubKey)) != 1 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
 // Serialize outputs a byte array that can be hashed to produce the canonical event "id".
 func (evt *Event) Serialize() []byte {
 	// the serialization process is just putting everything into a JSON array
@@ -64,34 +169,43 @@ func (evt *Event) Serialize() []byte {
 	return serializeEventInto(evt, dst)
 }
 
-func serializeEventInto(evt *Event, dst []byte) []byte {
-	// the header portion is easy to serialize
-	// [0,"pubkey",created_at,kind,[
-	dst = append(dst, "[0,\""...)
-	dst = append(dst, evt.PubKey...)
-	dst = append(dst, "\","...)
-	dst = append(dst, strconv.FormatInt(int64(evt.CreatedAt), 10)...)
-	dst = append(dst, ',')
-	dst = append(dst, strconv.Itoa(evt.Kind)...)
-	dst = append(dst, ',')
-
-	// tags
+// Serialize outputs the canonical JSON array encoding of tags, exactly as
+// embedded by serializeEventInto. It is exported so other packages (such as
+// nip44's AAD binding) can reuse the same byte-for-byte encoding instead of
+// re-implementing it.
+func (tags Tags) Serialize() []byte {
+	dst := make([]byte, 0, len(tags)*80)
 	dst = append(dst, '[')
-	for i, tag := range evt.Tags {
+	for i, tag := range tags {
 		if i > 0 {
 			dst = append(dst, ',')
 		}
-		// tag item
 		dst = append(dst, '[')
-		for i, s := range tag {
-			if i > 0 {
+		for j, s := range tag {
+			if j > 0 {
 				dst = append(dst, ',')
 			}
 			dst = escapeString(dst, s)
 		}
 		dst = append(dst, ']')
 	}
-	dst = append(dst, "],"...)
+	dst = append(dst, ']')
+	return dst
+}
+
+func serializeEventInto(evt *Event, dst []byte) []byte {
+	// the header portion is easy to serialize
+	// [0,"pubkey",created_at,kind,[
+	dst = append(dst, "[0,\""...)
+	dst = append(dst, evt.PubKey...)
+	dst = append(dst, "\","...)
+	dst = append(dst, strconv.FormatInt(int64(evt.CreatedAt), 10)...)
+	dst = append(dst, ',')
+	dst = append(dst, strconv.Itoa(evt.Kind)...)
+	dst = append(dst, ',')
+
+	dst = append(dst, evt.Tags.Serialize()...)
+	dst = append(dst, ',')
 
 	// content needs to be escaped in general as it is user generated.
 	dst = escapeString(dst, evt.Content)
@@ -0,0 +1,124 @@
+package nostr
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fixed test vector: any 32-byte non-zero scalar is a valid secp256k1 private key
+const testPrivateKeyHex = "0000000000000000000000000000000000000000000000000000000000000001"
+
+func TestEventSignAndCheckSignature(t *testing.T) {
+	evt := &Event{
+		CreatedAt: Timestamp(12345),
+		Kind:      1,
+		Tags:      Tags{},
+		Content:   "hello world",
+	}
+
+	if err := evt.Sign(testPrivateKeyHex); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if evt.PubKey == "" {
+		t.Fatalf("expected PubKey to be populated")
+	}
+	if evt.ID != evt.GetID() {
+		t.Fatalf("ID does not match GetID()")
+	}
+
+	ok, err := evt.CheckSignature()
+	if err != nil {
+		t.Fatalf("CheckSignature: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected signature to be valid")
+	}
+}
+
+func TestEventCheckSignatureRejectsTamperedContent(t *testing.T) {
+	evt := &Event{
+		CreatedAt: Timestamp(12345),
+		Kind:      1,
+		Tags:      Tags{},
+		Content:   "hello world",
+	}
+	if err := evt.Sign(testPrivateKeyHex); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	evt.Content = "goodbye world"
+
+	ok, err := evt.CheckSignature()
+	if err != nil {
+		t.Fatalf("CheckSignature: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected signature to be invalid after tampering")
+	}
+}
+
+func TestEventCheckSignatureRejectsWrongPubKey(t *testing.T) {
+	evt := &Event{
+		CreatedAt: Timestamp(12345),
+		Kind:      1,
+		Tags:      Tags{},
+		Content:   "hello world",
+	}
+	if err := evt.Sign(testPrivateKeyHex); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	evt.PubKey = "0000000000000000000000000000000000000000"
+
+	ok, err := evt.CheckSignature()
+	if err != nil {
+		t.Fatalf("CheckSignature: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected signature to be invalid for the wrong pubkey")
+	}
+}
+
+func TestEventCheckSignatureRejectsHighSMalleableSignature(t *testing.T) {
+	evt := &Event{
+		CreatedAt: Timestamp(12345),
+		Kind:      1,
+		Tags:      Tags{},
+		Content:   "hello world",
+	}
+	if err := evt.Sign(testPrivateKeyHex); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	sig, err := hex.DecodeString(evt.Sig)
+	if err != nil {
+		t.Fatalf("decoding signature: %v", err)
+	}
+
+	r := new(big.Int).SetBytes(sig[0:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	v := sig[64]
+
+	// (r, s, v) and (r, N-s, 1-v) recover to the same public key; a strict
+	// verifier must reject the high-S form as malleable.
+	n := crypto.S256().Params().N
+	highS := new(big.Int).Sub(n, s)
+
+	malleable := make([]byte, 65)
+	r.FillBytes(malleable[0:32])
+	highS.FillBytes(malleable[32:64])
+	malleable[64] = 1 - v
+
+	evt.Sig = hex.EncodeToString(malleable)
+
+	ok, err := evt.CheckSignature()
+	if err == nil {
+		t.Fatalf("expected CheckSignature to return an error for a high-S signature")
+	}
+	if ok {
+		t.Fatalf("expected CheckSignature to reject a high-S malleable signature")
+	}
+}
@@ -0,0 +1,48 @@
+package nip44
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	nostr "github.com/d5c5ceb0/go-nostr"
+)
+
+// aadForEvent computes sha256(pubkey || ":" || kind || ":" || created_at ||
+// ":" || tagsCanonical), the associated data EncryptForEvent/DecryptForEvent
+// bind ciphertexts to. tagsCanonical reuses Tags.Serialize (the same
+// encoding serializeEventInto embeds in the event id) so sender and
+// receiver always agree byte-for-byte.
+func aadForEvent(evt *nostr.Event) []byte {
+	buf := make([]byte, 0, len(evt.PubKey)+42)
+	buf = append(buf, evt.PubKey...)
+	buf = append(buf, ':')
+	buf = strconv.AppendInt(buf, int64(evt.Kind), 10)
+	buf = append(buf, ':')
+	buf = strconv.AppendInt(buf, int64(evt.CreatedAt), 10)
+	buf = append(buf, ':')
+	buf = append(buf, evt.Tags.Serialize()...)
+
+	sum := sha256.Sum256(buf)
+	return sum[:]
+}
+
+// EncryptForEvent encrypts plaintext and binds the resulting ciphertext to
+// evt's pubkey, kind, created_at and tags via AEAD associated data. A
+// payload produced this way can only be decrypted by DecryptForEvent
+// against the exact same event fields: if the ciphertext is replayed inside
+// a different event, decryption fails.
+func EncryptForEvent(evt *nostr.Event, plaintext string, key []byte) (string, error) {
+	return EncryptWithAAD(plaintext, key, aadForEvent(evt), EncryptOptions{})
+}
+
+// DecryptForEvent reverses EncryptForEvent, returning an error if evt's
+// fields don't match what the ciphertext was bound to at encryption time.
+func DecryptForEvent(evt *nostr.Event, payload string, key []byte) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64: %w", err)
+	}
+	return decryptV2(data, key, aadForEvent(evt))
+}
@@ -0,0 +1,81 @@
+package nip44
+
+import (
+	"encoding/base64"
+	"testing"
+
+	nostr "github.com/d5c5ceb0/go-nostr"
+)
+
+func testEvent() *nostr.Event {
+	return &nostr.Event{
+		PubKey:    "d8da6bf26964af9d7eed9e03e53415d37aa96045",
+		CreatedAt: nostr.Timestamp(1700000000),
+		Kind:      4,
+		Tags:      nostr.Tags{{"p", "abcd"}},
+	}
+}
+
+func TestEncryptDecryptForEventRoundTrip(t *testing.T) {
+	key := make([]byte, 32)
+	evt := testEvent()
+
+	payload, err := EncryptForEvent(evt, "bound message", key)
+	if err != nil {
+		t.Fatalf("EncryptForEvent: %v", err)
+	}
+
+	got, err := DecryptForEvent(evt, payload, key)
+	if err != nil {
+		t.Fatalf("DecryptForEvent: %v", err)
+	}
+	if got != "bound message" {
+		t.Fatalf("got %q, want %q", got, "bound message")
+	}
+}
+
+func TestDecryptForEventRejectsMutatedFields(t *testing.T) {
+	key := make([]byte, 32)
+	evt := testEvent()
+
+	payload, err := EncryptForEvent(evt, "bound message", key)
+	if err != nil {
+		t.Fatalf("EncryptForEvent: %v", err)
+	}
+
+	mutations := []func(*nostr.Event){
+		func(e *nostr.Event) { e.PubKey = "0000000000000000000000000000000000000000" },
+		func(e *nostr.Event) { e.Kind = 1 },
+		func(e *nostr.Event) { e.CreatedAt = e.CreatedAt + 1 },
+		func(e *nostr.Event) { e.Tags = nostr.Tags{{"p", "different"}} },
+	}
+
+	for i, mutate := range mutations {
+		mutated := testEvent()
+		mutate(mutated)
+		if _, err := DecryptForEvent(mutated, payload, key); err == nil {
+			t.Fatalf("mutation %d: expected DecryptForEvent to fail", i)
+		}
+	}
+}
+
+func TestEncryptWithAADRequiresMatchingAAD(t *testing.T) {
+	key := make([]byte, 32)
+
+	payload, err := EncryptWithAAD("custom binding", key, []byte("context-a"), EncryptOptions{})
+	if err != nil {
+		t.Fatalf("EncryptWithAAD: %v", err)
+	}
+
+	if _, err := decryptV2MustFailWithWrongAAD(payload, key); err == nil {
+		t.Fatalf("expected decryption with mismatched aad to fail")
+	}
+}
+
+func decryptV2MustFailWithWrongAAD(payload string, key []byte) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", err
+	}
+	return decryptV2(data, key, []byte("context-b"))
+}
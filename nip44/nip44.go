@@ -6,11 +6,20 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"io"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
 )
 
+// v2Salt is the fixed HKDF salt used to derive version-2 payload keys from
+// the shared secret returned by ComputeSharedSecret.
+var v2Salt = []byte("nip44-v2")
+
+const v2InfoEncrypt = "nip44-encrypt"
+
 // ComputeSharedSecret returns a shared secret key used to encrypt messages.
 // The private and public keys should be hex encoded.
 // Uses the Diffie-Hellman key exchange (ECDH) (RFC 4753).
@@ -67,15 +76,28 @@ func encryptWithNonce(message string, key []byte, nonce []byte) (string, error)
 
 // Decrypt decrypts a content string using the shared secret key.
 // The inverse operation to message -> Encrypt(message, key).
+// It dispatches on the version byte so payloads produced by EncryptV2 keep
+// working alongside the legacy v1 format; any other version is rejected.
 func Decrypt(payload string, key []byte) (string, error) {
 	data, err := base64.StdEncoding.DecodeString(payload)
 	if err != nil {
 		return "", fmt.Errorf("invalid base64: %w", err)
 	}
+	if len(data) == 0 {
+		return "", fmt.Errorf("invalid payload, empty")
+	}
 
-	if data[0] != 1 {
+	switch data[0] {
+	case 1:
+		return decryptV1(data, key)
+	case 2:
+		return decryptV2(data, key, nil)
+	default:
 		return "", fmt.Errorf("unknown version: %d", data[0])
 	}
+}
+
+func decryptV1(data []byte, key []byte) (string, error) {
 	if len(data) <= 25 {
 		return "", fmt.Errorf("invalid payload, too small: %d", len(data))
 	}
@@ -91,3 +113,105 @@ func Decrypt(payload string, key []byte) (string, error) {
 
 	return string(msg), nil
 }
+
+// EncryptOptions configures EncryptV2. The zero value generates a random
+// nonce; a caller-supplied Nonce is only meant for producing fixed test
+// vectors, never for normal use, since nonce reuse breaks AEAD security.
+type EncryptOptions struct {
+	Nonce []byte
+}
+
+// deriveV2Key expands the shared secret produced by ComputeSharedSecret
+// (the SHA-256 of the ECDH secret) into a 32-byte AEAD key using
+// HKDF-SHA256.
+func deriveV2Key(sharedSecret []byte) ([]byte, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedSecret, v2Salt, []byte(v2InfoEncrypt)), key); err != nil {
+		return nil, fmt.Errorf("error deriving encryption key: %w", err)
+	}
+	return key, nil
+}
+
+// EncryptV2 encrypts message with an authenticated cipher (XChaCha20-Poly1305),
+// deriving its key material from the ECDH shared secret via HKDF-SHA256.
+// key should be the shared secret generated by ComputeSharedSecret.
+// Returns: base64(0x02 || nonce[24] || ciphertext || tag[16]).
+func EncryptV2(message string, key []byte, opts EncryptOptions) (string, error) {
+	return EncryptWithAAD(message, key, nil, opts)
+}
+
+// EncryptWithAAD is the lower-level primitive behind EncryptV2: it seals
+// message the same way, but binds the ciphertext to the given associated
+// data, so decryption that doesn't supply the identical aad fails
+// authentication. Decrypt and DecryptV2 supply no aad, and this package
+// exports no decrypt function taking an arbitrary aad; DecryptForEvent
+// supplies the event-shaped aad that EncryptForEvent binds.
+// Returns: base64(0x02 || nonce[24] || ciphertext || tag[16]).
+func EncryptWithAAD(message string, key, aad []byte, opts EncryptOptions) (string, error) {
+	aeadKey, err := deriveV2Key(key)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := chacha20poly1305.NewX(aeadKey)
+	if err != nil {
+		return "", fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	nonce := opts.Nonce
+	if nonce == nil {
+		nonce = make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return "", fmt.Errorf("error creating nonce: %w", err)
+		}
+	} else if len(nonce) != aead.NonceSize() {
+		return "", fmt.Errorf("invalid nonce size: got %d, want %d", len(nonce), aead.NonceSize())
+	}
+
+	payload := make([]byte, 0, 1+len(nonce)+len(message)+aead.Overhead())
+	payload = append(payload, 2)
+	payload = append(payload, nonce...)
+	payload = aead.Seal(payload, nonce, []byte(message), aad)
+
+	return base64.StdEncoding.EncodeToString(payload), nil
+}
+
+// DecryptV2 decrypts a payload produced by EncryptV2, returning an error if
+// the authentication tag does not match.
+func DecryptV2(payload string, key []byte) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64: %w", err)
+	}
+	return decryptV2(data, key, nil)
+}
+
+func decryptV2(data []byte, key, aad []byte) (string, error) {
+	aeadKey, err := deriveV2Key(key)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := chacha20poly1305.NewX(aeadKey)
+	if err != nil {
+		return "", fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	nonceEnd := 1 + aead.NonceSize()
+	if len(data) <= nonceEnd {
+		return "", fmt.Errorf("invalid payload, too small: %d", len(data))
+	}
+	if data[0] != 2 {
+		return "", fmt.Errorf("unknown version: %d", data[0])
+	}
+
+	nonce := data[1:nonceEnd]
+	ciphertext := data[nonceEnd:]
+
+	msg, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate and decrypt payload: %w", err)
+	}
+
+	return string(msg), nil
+}
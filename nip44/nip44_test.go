@@ -0,0 +1,104 @@
+package nip44
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncryptV2DecryptV2RoundTrip(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	payload, err := EncryptV2("hello from v2", key, EncryptOptions{})
+	if err != nil {
+		t.Fatalf("EncryptV2: %v", err)
+	}
+
+	got, err := DecryptV2(payload, key)
+	if err != nil {
+		t.Fatalf("DecryptV2: %v", err)
+	}
+	if got != "hello from v2" {
+		t.Fatalf("got %q, want %q", got, "hello from v2")
+	}
+
+	// also reachable through the version-dispatching Decrypt
+	got, err = Decrypt(payload, key)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got != "hello from v2" {
+		t.Fatalf("got %q, want %q", got, "hello from v2")
+	}
+}
+
+func TestEncryptV2FixedNonceVector(t *testing.T) {
+	key := make([]byte, 32)
+	nonce := make([]byte, 24)
+	for i := range nonce {
+		nonce[i] = byte(0xff - i)
+	}
+
+	payload, err := EncryptV2("fixed vector", key, EncryptOptions{Nonce: nonce})
+	if err != nil {
+		t.Fatalf("EncryptV2: %v", err)
+	}
+
+	payload2, err := EncryptV2("fixed vector", key, EncryptOptions{Nonce: nonce})
+	if err != nil {
+		t.Fatalf("EncryptV2: %v", err)
+	}
+	if payload != payload2 {
+		t.Fatalf("same key+nonce+message should produce identical ciphertext")
+	}
+
+	got, err := DecryptV2(payload, key)
+	if err != nil {
+		t.Fatalf("DecryptV2: %v", err)
+	}
+	if got != "fixed vector" {
+		t.Fatalf("got %q, want %q", got, "fixed vector")
+	}
+}
+
+func TestDecryptRejectsCrossVersionPayload(t *testing.T) {
+	key := make([]byte, 32)
+
+	v1Payload, err := Encrypt("legacy message", key)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if _, err := DecryptV2(v1Payload, key); err == nil {
+		t.Fatalf("expected DecryptV2 to reject a v1 payload")
+	}
+
+	// but the version-dispatching Decrypt still accepts it
+	if got, err := Decrypt(v1Payload, key); err != nil || got != "legacy message" {
+		t.Fatalf("Decrypt(v1Payload) = %q, %v", got, err)
+	}
+
+	v2Payload, err := EncryptV2("authenticated message", key, EncryptOptions{})
+	if err != nil {
+		t.Fatalf("EncryptV2: %v", err)
+	}
+	otherKey := make([]byte, 32)
+	otherKey[0] = 1
+	if _, err := DecryptV2(v2Payload, otherKey); err == nil {
+		t.Fatalf("expected DecryptV2 to fail authentication with the wrong key")
+	}
+
+	if _, err := Decrypt("not-base64!!", key); err == nil {
+		t.Fatalf("expected Decrypt to reject invalid base64")
+	}
+	if !strings.Contains(func() string {
+		_, err := Decrypt("AA==", key)
+		if err == nil {
+			return ""
+		}
+		return err.Error()
+	}(), "unknown version") {
+		t.Fatalf("expected an unknown version error for an unrecognized version byte")
+	}
+}
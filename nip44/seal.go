@@ -0,0 +1,136 @@
+package nip44
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// sealSalt is the fixed HKDF salt used to derive sealed-envelope keys from
+// an ECIES shared secret, kept distinct from v2Salt so the two schemes
+// never collide even if a caller reused a secret between them.
+var sealSalt = []byte("nip44-seal")
+
+const sealInfoEncrypt = "nip44-seal-encrypt"
+
+const sealVersion = 0x81
+
+// deriveSealKey expands an ECIES shared secret into a 32-byte AEAD key
+// using HKDF-SHA256, the same derivation shape as deriveV2Key but with a
+// domain-separated salt/info so sealed-envelope keys are never reachable
+// from the DH-based v2 path.
+func deriveSealKey(sharedSecret []byte) ([]byte, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedSecret, sealSalt, []byte(sealInfoEncrypt)), key); err != nil {
+		return nil, fmt.Errorf("error deriving seal key: %w", err)
+	}
+	return key, nil
+}
+
+// Seal encrypts message to recipientPubHex (a hex-encoded, x-only
+// secp256k1 public key, as used elsewhere in this package) without
+// requiring the sender to hold a long-lived key. It generates a fresh
+// ephemeral keypair, performs ECDH with the recipient, and seals the
+// message with XChaCha20-Poly1305 under a key derived from that shared
+// secret via HKDF-SHA256.
+// Returns: base64(0x81 || ephemeralCompressedPub[33] || nonce[24] || ct || tag[16]).
+func Seal(message string, recipientPubHex string) (string, error) {
+	recipientPubBytes, err := hex.DecodeString("02" + recipientPubHex)
+	if err != nil {
+		return "", fmt.Errorf("error decoding hex string of recipient public key '%s': %w", "02"+recipientPubHex, err)
+	}
+	recipientPub, err := btcec.ParsePubKey(recipientPubBytes)
+	if err != nil {
+		return "", fmt.Errorf("error parsing recipient public key '%s': %w", "02"+recipientPubHex, err)
+	}
+
+	ephemeral, err := btcec.NewPrivateKey()
+	if err != nil {
+		return "", fmt.Errorf("error generating ephemeral key: %w", err)
+	}
+	defer ephemeral.Zero()
+
+	sharedSecret := btcec.GenerateSharedSecret(ephemeral, recipientPub)
+	key, err := deriveSealKey(sharedSecret)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return "", fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("error creating nonce: %w", err)
+	}
+
+	ephemeralPub := ephemeral.PubKey().SerializeCompressed()
+
+	payload := make([]byte, 0, 1+len(ephemeralPub)+len(nonce)+len(message)+aead.Overhead())
+	payload = append(payload, sealVersion)
+	payload = append(payload, ephemeralPub...)
+	payload = append(payload, nonce...)
+	payload = aead.Seal(payload, nonce, []byte(message), nil)
+
+	return base64.StdEncoding.EncodeToString(payload), nil
+}
+
+// Open reverses Seal using the recipient's hex-encoded private key. It
+// returns an error if the payload is malformed or fails authentication.
+func Open(payload string, recipientSkHex string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64: %w", err)
+	}
+
+	const headerLen = 1 + 33 + 24
+	if len(data) <= headerLen {
+		return "", fmt.Errorf("invalid payload, too small: %d", len(data))
+	}
+	if data[0] != sealVersion {
+		return "", fmt.Errorf("unknown version: %d", data[0])
+	}
+
+	ephemeralPubBytes := data[1:34]
+	nonce := data[34:headerLen]
+	ciphertext := data[headerLen:]
+
+	ephemeralPub, err := btcec.ParsePubKey(ephemeralPubBytes)
+	if err != nil {
+		return "", fmt.Errorf("error parsing ephemeral public key: %w", err)
+	}
+
+	privKeyBytes, err := hex.DecodeString(recipientSkHex)
+	if err != nil {
+		return "", fmt.Errorf("error decoding recipient private key: %w", err)
+	}
+	recipientPriv, _ := btcec.PrivKeyFromBytes(privKeyBytes)
+	defer recipientPriv.Zero()
+
+	sharedSecret := btcec.GenerateSharedSecret(recipientPriv, ephemeralPub)
+	key, err := deriveSealKey(sharedSecret)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return "", fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	msg, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate and decrypt payload: %w", err)
+	}
+
+	return string(msg), nil
+}
@@ -0,0 +1,74 @@
+package nip44
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+func generateTestKeypair(t *testing.T) (skHex string, pubHex string) {
+	t.Helper()
+	sk, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey: %v", err)
+	}
+	pub := sk.PubKey().SerializeCompressed()
+	return hex.EncodeToString(sk.Serialize()), hex.EncodeToString(pub[1:])
+}
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	sk, pub := generateTestKeypair(t)
+
+	payload, err := Seal("a secret message", pub)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	got, err := Open(payload, sk)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if got != "a secret message" {
+		t.Fatalf("got %q, want %q", got, "a secret message")
+	}
+}
+
+func TestSealProducesDistinctCiphertexts(t *testing.T) {
+	_, pub := generateTestKeypair(t)
+
+	p1, err := Seal("same message", pub)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	p2, err := Seal("same message", pub)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if p1 == p2 {
+		t.Fatalf("expected two Seal calls on the same plaintext to produce different ciphertexts")
+	}
+}
+
+func TestOpenRejectsTamperedEnvelope(t *testing.T) {
+	sk, pub := generateTestKeypair(t)
+
+	payload, err := Seal("tamper me", pub)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	for _, idx := range []int{0, 1, 1 + 33, len(raw) - 1} {
+		tampered := append([]byte(nil), raw...)
+		tampered[idx] ^= 0xff
+		if _, err := Open(base64.StdEncoding.EncodeToString(tampered), sk); err == nil {
+			t.Fatalf("expected Open to fail after tampering with byte %d", idx)
+		}
+	}
+}